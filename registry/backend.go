@@ -0,0 +1,120 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// Backend is implemented by each storage strategy for the handler
+// catalog. The in-memory backend is the original behavior; the
+// ConfigMap and etcd backends persist handler metadata and last-run
+// status outside the process so multiple replicas see a consistent
+// catalog.
+type Backend interface {
+	Register(handler Handler) error
+	Get(name string) (Handler, error)
+	List() map[string]string
+	Execute(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string) (interface{}, error)
+}
+
+// StatusRecorder is implemented by backends that persist last-run
+// status outside their in-memory handler map (configmap, etcd). The
+// registry calls RecordExecution after a ParamHandler runs via
+// ExecuteWithParams, since that path calls the handler directly rather
+// than through Backend.Execute. Backends that don't persist status,
+// like the in-memory backend, don't need to implement it.
+type StatusRecorder interface {
+	RecordExecution(ctx context.Context, name string, err error)
+}
+
+// Option configures a Backend at construction time. A given backend
+// ignores options it doesn't use.
+type Option func(*options)
+
+type options struct {
+	clientset     *kubernetes.Clientset
+	namespace     string
+	configMapName string
+	etcdEndpoints []string
+}
+
+// WithClientset supplies the Kubernetes clientset a backend uses to
+// persist its state (configmap backend).
+func WithClientset(clientset *kubernetes.Clientset) Option {
+	return func(o *options) { o.clientset = clientset }
+}
+
+// WithNamespace sets the namespace a backend persists its state in.
+func WithNamespace(namespace string) Option {
+	return func(o *options) { o.namespace = namespace }
+}
+
+// WithConfigMapName overrides the default ConfigMap name used by the
+// configmap backend.
+func WithConfigMapName(name string) Option {
+	return func(o *options) { o.configMapName = name }
+}
+
+// WithEtcdEndpoints sets the etcd cluster endpoints used by the etcd
+// backend.
+func WithEtcdEndpoints(endpoints ...string) Option {
+	return func(o *options) { o.etcdEndpoints = endpoints }
+}
+
+// Backends maps a backend name, as used in the HANDLER_REGISTRY env var,
+// to a constructor for it. New backend implementations register
+// themselves here.
+var Backends = map[string]func(opts ...Option) Backend{
+	"memory":    newMemoryBackend,
+	"configmap": newConfigMapBackend,
+	"etcd":      newEtcdBackend,
+}
+
+var (
+	activeMu sync.RWMutex
+	active   Backend = newMemoryBackend()
+)
+
+// currentBackend returns the active backend under a read lock.
+func currentBackend() Backend {
+	activeMu.RLock()
+	defer activeMu.RUnlock()
+
+	return active
+}
+
+// Use switches the active backend to the one registered under name,
+// constructing it with opts. Handlers already registered against the
+// previous backend are not migrated.
+func Use(name string, opts ...Option) error {
+	factory, ok := Backends[name]
+	if !ok {
+		return fmt.Errorf("unknown handler registry backend: %s", name)
+	}
+
+	backend := factory(opts...)
+
+	activeMu.Lock()
+	active = backend
+	activeMu.Unlock()
+
+	return nil
+}
+
+// init selects the backend named by the HANDLER_REGISTRY env var
+// (memory|configmap|etcd) at startup, defaulting to the in-memory
+// backend when unset.
+func init() {
+	name := os.Getenv("HANDLER_REGISTRY")
+	if name == "" {
+		return
+	}
+
+	if err := Use(name); err != nil {
+		fmt.Printf("[Registry] %v, falling back to in-memory backend\n", err)
+	}
+}
@@ -0,0 +1,178 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultConfigMapNamespace = "kube-system"
+	defaultConfigMapName      = "handler-registry"
+)
+
+// configMapBackend keeps handler objects in memory, since Go interfaces
+// can't be serialized, but persists each handler's metadata and
+// last-run status into a well-known ConfigMap so every replica sees a
+// consistent view of the catalog.
+type configMapBackend struct {
+	handlerMap
+	clientset *kubernetes.Clientset
+	namespace string
+	name      string
+
+	// writeMu serializes ConfigMap writes from this process: Register
+	// fires putStatus from a goroutine per handler, and without this
+	// lock two concurrent first-writers would both see IsNotFound and
+	// race on Create.
+	writeMu sync.Mutex
+}
+
+// configMapStatus is persisted as JSON under the handler's key in the
+// ConfigMap's Data map.
+type configMapStatus struct {
+	Description string    `json:"description"`
+	LastRun     time.Time `json:"lastRun,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+func newConfigMapBackend(opts ...Option) Backend {
+	o := &options{
+		namespace:     defaultConfigMapNamespace,
+		configMapName: defaultConfigMapName,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return &configMapBackend{
+		handlerMap: newHandlerMap(),
+		clientset:  o.clientset,
+		namespace:  o.namespace,
+		name:       o.configMapName,
+	}
+}
+
+func (b *configMapBackend) Register(handler Handler) error {
+	if err := b.add(handler); err != nil {
+		return err
+	}
+
+	// Persist metadata in the background so registering N handlers at
+	// process startup doesn't serialize on N ConfigMap round-trips.
+	name := handler.Name()
+	description := handler.Description()
+	go func() {
+		if err := b.putStatus(context.Background(), name, configMapStatus{Description: description}); err != nil {
+			fmt.Printf("[Registry] failed to persist status for %s: %v\n", name, err)
+		}
+	}()
+
+	return nil
+}
+
+func (b *configMapBackend) Get(name string) (Handler, error) { return b.get(name) }
+
+func (b *configMapBackend) List() map[string]string { return b.list() }
+
+func (b *configMapBackend) Execute(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	handler, err := b.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, execErr := handler.Execute(ctx, clientset, namespace)
+	b.RecordExecution(ctx, name, execErr)
+
+	return result, execErr
+}
+
+// RecordExecution upserts a handler's last-run status into the
+// backend's ConfigMap. Execute calls it directly; the registry also
+// calls it for handlers invoked via ExecuteWithParams, which bypasses
+// Backend.Execute entirely.
+func (b *configMapBackend) RecordExecution(ctx context.Context, name string, execErr error) {
+	handler, err := b.get(name)
+	if err != nil {
+		return
+	}
+
+	status := configMapStatus{Description: handler.Description(), LastRun: time.Now()}
+	if execErr != nil {
+		status.LastError = execErr.Error()
+	}
+	if err := b.putStatus(ctx, name, status); err != nil {
+		fmt.Printf("[Registry] failed to persist status for %s: %v\n", name, err)
+	}
+}
+
+// maxPutStatusAttempts bounds retries against concurrent writers (other
+// replicas) racing to create or update the same ConfigMap.
+const maxPutStatusAttempts = 3
+
+// putStatus upserts the handler's metadata and last-run status into the
+// backend's ConfigMap, creating the ConfigMap on first use. writeMu
+// serializes writes from this process; AlreadyExists/Conflict errors
+// from a concurrent writer in another replica are retried.
+func (b *configMapBackend) putStatus(ctx context.Context, name string, status configMapStatus) error {
+	if b.clientset == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	b.writeMu.Lock()
+	defer b.writeMu.Unlock()
+
+	cms := b.clientset.CoreV1().ConfigMaps(b.namespace)
+
+	var lastErr error
+	for attempt := 0; attempt < maxPutStatusAttempts; attempt++ {
+		cm, getErr := cms.Get(ctx, b.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(getErr) {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: b.name, Namespace: b.namespace},
+				Data:       map[string]string{name: string(encoded)},
+			}
+
+			_, createErr := cms.Create(ctx, cm, metav1.CreateOptions{})
+			if createErr == nil {
+				return nil
+			}
+			if !apierrors.IsAlreadyExists(createErr) {
+				return createErr
+			}
+			lastErr = createErr
+			continue
+		}
+		if getErr != nil {
+			return getErr
+		}
+
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[name] = string(encoded)
+
+		_, updateErr := cms.Update(ctx, cm, metav1.UpdateOptions{})
+		if updateErr == nil {
+			return nil
+		}
+		if !apierrors.IsConflict(updateErr) {
+			return updateErr
+		}
+		lastErr = updateErr
+	}
+
+	return fmt.Errorf("putStatus: giving up after %d attempts: %w", maxPutStatusAttempts, lastErr)
+}
@@ -0,0 +1,132 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultEtcdKeyPrefix = "/handler-registry/"
+	defaultEtcdTimeout   = 5 * time.Second
+)
+
+// etcdBackend keeps handler objects in memory, since Go interfaces
+// can't be serialized, but persists each handler's metadata and
+// last-run status under a well-known etcd key prefix so the catalog is
+// consistent across every replica in a multi-replica deployment.
+type etcdBackend struct {
+	handlerMap
+	client *clientv3.Client
+	prefix string
+}
+
+// etcdStatus is the JSON document stored under each handler's etcd key.
+type etcdStatus struct {
+	Description string    `json:"description"`
+	LastRun     time.Time `json:"lastRun,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+}
+
+func newEtcdBackend(opts ...Option) Backend {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	b := &etcdBackend{
+		handlerMap: newHandlerMap(),
+		prefix:     defaultEtcdKeyPrefix,
+	}
+
+	if len(o.etcdEndpoints) > 0 {
+		client, err := clientv3.New(clientv3.Config{
+			Endpoints:   o.etcdEndpoints,
+			DialTimeout: defaultEtcdTimeout,
+		})
+		if err != nil {
+			fmt.Printf("[Registry] failed to connect to etcd: %v\n", err)
+		} else {
+			b.client = client
+		}
+	}
+
+	return b
+}
+
+func (b *etcdBackend) Register(handler Handler) error {
+	if err := b.add(handler); err != nil {
+		return err
+	}
+
+	// Persist metadata in the background so registering N handlers at
+	// process startup doesn't serialize on N etcd round-trips.
+	name := handler.Name()
+	description := handler.Description()
+	go func() {
+		if err := b.putStatus(context.Background(), name, etcdStatus{Description: description}); err != nil {
+			fmt.Printf("[Registry] failed to persist status for %s: %v\n", name, err)
+		}
+	}()
+
+	return nil
+}
+
+func (b *etcdBackend) Get(name string) (Handler, error) { return b.get(name) }
+
+func (b *etcdBackend) List() map[string]string { return b.list() }
+
+func (b *etcdBackend) Execute(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	handler, err := b.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	result, execErr := handler.Execute(ctx, clientset, namespace)
+	b.RecordExecution(ctx, name, execErr)
+
+	return result, execErr
+}
+
+// RecordExecution upserts a handler's last-run status under the
+// backend's etcd key prefix. Execute calls it directly; the registry
+// also calls it for handlers invoked via ExecuteWithParams, which
+// bypasses Backend.Execute entirely.
+func (b *etcdBackend) RecordExecution(ctx context.Context, name string, execErr error) {
+	handler, err := b.get(name)
+	if err != nil {
+		return
+	}
+
+	status := etcdStatus{Description: handler.Description(), LastRun: time.Now()}
+	if execErr != nil {
+		status.LastError = execErr.Error()
+	}
+	if err := b.putStatus(ctx, name, status); err != nil {
+		fmt.Printf("[Registry] failed to persist status for %s: %v\n", name, err)
+	}
+}
+
+// putStatus upserts the handler's metadata and last-run status under
+// the backend's etcd key prefix.
+func (b *etcdBackend) putStatus(ctx context.Context, name string, status etcdStatus) error {
+	if b.client == nil {
+		return nil
+	}
+
+	encoded, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	putCtx, cancel := context.WithTimeout(ctx, defaultEtcdTimeout)
+	defer cancel()
+
+	_, err = b.client.Put(putCtx, b.prefix+name, string(encoded))
+
+	return err
+}
@@ -0,0 +1,41 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// memoryBackend is the default Backend: handlers live only in this
+// process's memory, matching the module's original behavior.
+type memoryBackend struct {
+	handlerMap
+}
+
+func newMemoryBackend(opts ...Option) Backend {
+	return &memoryBackend{handlerMap: newHandlerMap()}
+}
+
+func (b *memoryBackend) Register(handler Handler) error {
+	if err := b.add(handler); err != nil {
+		return err
+	}
+
+	fmt.Printf("[Registry] Registered handler: %s - %s\n", handler.Name(), handler.Description())
+
+	return nil
+}
+
+func (b *memoryBackend) Get(name string) (Handler, error) { return b.get(name) }
+
+func (b *memoryBackend) List() map[string]string { return b.list() }
+
+func (b *memoryBackend) Execute(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	handler, err := b.get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.Execute(ctx, clientset, namespace)
+}
@@ -0,0 +1,125 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// backendTestHandler is a minimal Handler fixture for exercising
+// handlerMap and backend-dispatch behavior directly, without needing a
+// real or fake Kubernetes clientset.
+type backendTestHandler struct {
+	name string
+}
+
+func (h *backendTestHandler) Name() string        { return h.name }
+func (h *backendTestHandler) Description() string { return "fake handler for backend tests" }
+func (h *backendTestHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return nil, nil
+}
+
+func TestHandlerMapAddGetList(t *testing.T) {
+	hm := newHandlerMap()
+	handler := &backendTestHandler{name: "hm-test-handler"}
+
+	if err := hm.add(handler); err != nil {
+		t.Fatalf("add: %v", err)
+	}
+
+	got, err := hm.get(handler.name)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if got != handler {
+		t.Fatalf("get returned %v, want the handler that was added", got)
+	}
+
+	if _, err := hm.get("missing"); err == nil {
+		t.Fatal("get of an unregistered name should error")
+	}
+
+	list := hm.list()
+	if list[handler.name] != handler.Description() {
+		t.Fatalf("list()[%s] = %q, want %q", handler.name, list[handler.name], handler.Description())
+	}
+}
+
+func TestHandlerMapAddRejectsDuplicateName(t *testing.T) {
+	hm := newHandlerMap()
+	first := &backendTestHandler{name: "hm-test-duplicate"}
+	second := &backendTestHandler{name: "hm-test-duplicate"}
+
+	if err := hm.add(first); err != nil {
+		t.Fatalf("add(first): %v", err)
+	}
+	if err := hm.add(second); err == nil {
+		t.Fatal("add of an already-registered name should error")
+	}
+
+	// The original registration must survive a rejected duplicate.
+	got, err := hm.get(first.name)
+	if err != nil {
+		t.Fatalf("get after rejected duplicate: %v", err)
+	}
+	if got != first {
+		t.Fatal("duplicate add replaced the original handler")
+	}
+}
+
+// withRestoredBackend saves the active backend and restores it after the
+// test, so switching backends via Use doesn't leak into other tests.
+func withRestoredBackend(t *testing.T) {
+	t.Helper()
+
+	activeMu.Lock()
+	saved := active
+	activeMu.Unlock()
+
+	t.Cleanup(func() {
+		activeMu.Lock()
+		active = saved
+		activeMu.Unlock()
+	})
+}
+
+func TestUseRejectsUnknownBackendName(t *testing.T) {
+	withRestoredBackend(t)
+
+	if err := Use("not-a-real-backend"); err == nil {
+		t.Fatal("Use with an unregistered backend name should error")
+	}
+}
+
+func TestUseSwitchesToNamedBackendFromRegistry(t *testing.T) {
+	withRestoredBackend(t)
+
+	if err := Use("memory"); err != nil {
+		t.Fatalf("Use(memory): %v", err)
+	}
+
+	handler := &backendTestHandler{name: "use-test-handler"}
+	Register(handler)
+
+	if _, err := Get(handler.name); err != nil {
+		t.Fatalf("Get after Register on the memory backend: %v", err)
+	}
+}
+
+func TestUseConstructsAFreshBackendNotMigratingExistingHandlers(t *testing.T) {
+	withRestoredBackend(t)
+
+	if err := Use("memory"); err != nil {
+		t.Fatalf("first Use(memory): %v", err)
+	}
+	Register(&backendTestHandler{name: "use-test-stale-handler"})
+
+	if err := Use("memory"); err != nil {
+		t.Fatalf("second Use(memory): %v", err)
+	}
+
+	if _, err := Get("use-test-stale-handler"); err == nil {
+		t.Fatal("handler registered against the previous backend instance should not carry over")
+	}
+}
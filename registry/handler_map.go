@@ -0,0 +1,58 @@
+package registry
+
+import (
+	"fmt"
+	"sync"
+)
+
+// handlerMap is the name->Handler bookkeeping shared by every Backend:
+// registration, lookup, and listing. Backends that persist additional
+// state (configmap, etcd) embed it and layer their own persistence on
+// top instead of duplicating this map and its locking.
+type handlerMap struct {
+	mu       sync.RWMutex
+	handlers map[string]Handler
+}
+
+func newHandlerMap() handlerMap {
+	return handlerMap{handlers: make(map[string]Handler)}
+}
+
+// add registers handler under its name, failing if that name is
+// already taken.
+func (h *handlerMap) add(handler Handler) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	name := handler.Name()
+	if _, exists := h.handlers[name]; exists {
+		return fmt.Errorf("handler %s already registered", name)
+	}
+	h.handlers[name] = handler
+
+	return nil
+}
+
+func (h *handlerMap) get(name string) (Handler, error) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	handler, exists := h.handlers[name]
+	if !exists {
+		return nil, fmt.Errorf("handler %s not found", name)
+	}
+
+	return handler, nil
+}
+
+func (h *handlerMap) list() map[string]string {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	result := make(map[string]string, len(h.handlers))
+	for name, handler := range h.handlers {
+		result[name] = handler.Description()
+	}
+
+	return result
+}
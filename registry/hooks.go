@@ -0,0 +1,91 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// HookPhase identifies where in a handler's execution a Hook runs.
+type HookPhase string
+
+const (
+	// PreExecute hooks run before the handler is invoked.
+	PreExecute HookPhase = "PreExecute"
+	// PostExecute hooks run after the handler returns successfully.
+	PostExecute HookPhase = "PostExecute"
+	// OnError hooks run after the handler returns an error.
+	OnError HookPhase = "OnError"
+)
+
+// FailurePolicy controls what Execute does when a hook itself returns an
+// error.
+type FailurePolicy int
+
+const (
+	// Abort stops Execute and returns the hook's error. This is the
+	// default.
+	Abort FailurePolicy = iota
+	// Continue logs the hook's error and runs the remaining hooks.
+	Continue
+)
+
+// Hook is a cross-cutting concern that runs around handler execution,
+// inspired by Helm's lifecycle hooks. Hooks are ordered within a phase
+// by ascending Weight().
+type Hook interface {
+	Name() string
+	Phase() HookPhase
+	Weight() int
+	Run(ctx context.Context, info HandlerInfo, result interface{}, err error) error
+}
+
+var (
+	hooksMu       sync.RWMutex
+	hooks         = make(map[HookPhase][]Hook)
+	failurePolicy = Abort
+)
+
+// RegisterHook adds a hook to its declared phase, keeping that phase's
+// hooks sorted by ascending weight.
+func RegisterHook(h Hook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	phase := h.Phase()
+	hooks[phase] = append(hooks[phase], h)
+	sort.SliceStable(hooks[phase], func(i, j int) bool {
+		return hooks[phase][i].Weight() < hooks[phase][j].Weight()
+	})
+}
+
+// SetFailurePolicy controls whether a failing hook aborts the rest of
+// Execute (Abort, the default) or is logged and skipped (Continue).
+func SetFailurePolicy(p FailurePolicy) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+
+	failurePolicy = p
+}
+
+// runHooks invokes every hook registered for phase, in weight order,
+// stopping early and returning the first error if the failure policy is
+// Abort.
+func runHooks(ctx context.Context, phase HookPhase, info HandlerInfo, result interface{}, execErr error) error {
+	hooksMu.RLock()
+	phaseHooks := append([]Hook(nil), hooks[phase]...)
+	policy := failurePolicy
+	hooksMu.RUnlock()
+
+	for _, h := range phaseHooks {
+		if err := h.Run(ctx, info, result, execErr); err != nil {
+			fmt.Printf("[Registry] hook %s (%s) failed: %v\n", h.Name(), phase, err)
+			if policy == Abort {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
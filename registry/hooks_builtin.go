@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AuditLogHook emits a structured log line for every handler execution
+// it observes. Register one instance per phase it should cover, e.g.
+// NewAuditLogHook(registry.PostExecute) and
+// NewAuditLogHook(registry.OnError).
+type AuditLogHook struct {
+	phase HookPhase
+}
+
+// NewAuditLogHook returns an audit logger that runs in phase.
+func NewAuditLogHook(phase HookPhase) *AuditLogHook {
+	return &AuditLogHook{phase: phase}
+}
+
+func (h *AuditLogHook) Name() string     { return "audit-log" }
+func (h *AuditLogHook) Phase() HookPhase { return h.phase }
+func (h *AuditLogHook) Weight() int      { return 0 }
+
+func (h *AuditLogHook) Run(ctx context.Context, info HandlerInfo, result interface{}, err error) error {
+	duration := ""
+	if !info.StartedAt.IsZero() {
+		duration = time.Since(info.StartedAt).String()
+	}
+
+	fmt.Printf("[Audit] phase=%s handler=%s namespace=%s duration=%s err=%v\n",
+		h.phase, info.Name, info.Namespace, duration, err)
+
+	return nil
+}
+
+// MetricsHook emits Prometheus-style timing and counter lines for
+// handler execution. Register one instance per phase it should cover,
+// e.g. NewMetricsHook(registry.PostExecute) and
+// NewMetricsHook(registry.OnError).
+type MetricsHook struct {
+	phase HookPhase
+}
+
+// NewMetricsHook returns a metrics emitter that runs in phase.
+func NewMetricsHook(phase HookPhase) *MetricsHook {
+	return &MetricsHook{phase: phase}
+}
+
+func (h *MetricsHook) Name() string     { return "prometheus-metrics" }
+func (h *MetricsHook) Phase() HookPhase { return h.phase }
+func (h *MetricsHook) Weight() int      { return 10 }
+
+func (h *MetricsHook) Run(ctx context.Context, info HandlerInfo, result interface{}, err error) error {
+	if !info.StartedAt.IsZero() {
+		fmt.Printf("handler_execution_duration_seconds{handler=%q} %f\n",
+			info.Name, time.Since(info.StartedAt).Seconds())
+	}
+
+	fmt.Printf("handler_execution_total{handler=%q,success=%t} 1\n", info.Name, err == nil)
+
+	return nil
+}
+
+// RBACPreflightHook runs a SelfSubjectAccessReview before the handler
+// executes, failing the request if the caller's clientset isn't
+// authorized to perform verb against resource in the target namespace.
+type RBACPreflightHook struct {
+	verb     string
+	resource string
+}
+
+// NewRBACPreflightHook returns a PreExecute hook that checks verb
+// access to resource before a handler runs.
+func NewRBACPreflightHook(verb, resource string) *RBACPreflightHook {
+	return &RBACPreflightHook{verb: verb, resource: resource}
+}
+
+func (h *RBACPreflightHook) Name() string     { return "rbac-preflight" }
+func (h *RBACPreflightHook) Phase() HookPhase { return PreExecute }
+func (h *RBACPreflightHook) Weight() int      { return -100 }
+
+func (h *RBACPreflightHook) Run(ctx context.Context, info HandlerInfo, result interface{}, err error) error {
+	if info.Clientset == nil {
+		return nil
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{
+		Spec: authorizationv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: info.Namespace,
+				Verb:      h.verb,
+				Resource:  h.resource,
+			},
+		},
+	}
+
+	reviewResult, reviewErr := info.Clientset.AuthorizationV1().SelfSubjectAccessReviews().Create(ctx, review, metav1.CreateOptions{})
+	if reviewErr != nil {
+		return fmt.Errorf("rbac preflight: %w", reviewErr)
+	}
+
+	if !reviewResult.Status.Allowed {
+		return fmt.Errorf("rbac preflight: not allowed to %s %s in namespace %s", h.verb, h.resource, info.Namespace)
+	}
+
+	return nil
+}
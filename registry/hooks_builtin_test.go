@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestAuditLogHookRunNeverErrors(t *testing.T) {
+	hook := NewAuditLogHook(PostExecute)
+
+	if hook.Phase() != PostExecute {
+		t.Fatalf("Phase() = %v, want PostExecute", hook.Phase())
+	}
+
+	info := HandlerInfo{Name: "audit-test-handler", Namespace: "default", StartedAt: time.Now()}
+	if err := hook.Run(context.Background(), info, "some result", nil); err != nil {
+		t.Fatalf("Run with no execution error: %v", err)
+	}
+	if err := hook.Run(context.Background(), HandlerInfo{Name: "audit-test-handler"}, nil, context.DeadlineExceeded); err != nil {
+		t.Fatalf("Run with an execution error and zero StartedAt: %v", err)
+	}
+}
+
+func TestMetricsHookRunNeverErrors(t *testing.T) {
+	hook := NewMetricsHook(OnError)
+
+	if hook.Phase() != OnError {
+		t.Fatalf("Phase() = %v, want OnError", hook.Phase())
+	}
+	if hook.Weight() != 10 {
+		t.Fatalf("Weight() = %d, want 10", hook.Weight())
+	}
+
+	info := HandlerInfo{Name: "metrics-test-handler", StartedAt: time.Now()}
+	if err := hook.Run(context.Background(), info, nil, context.DeadlineExceeded); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+}
+
+// RBACPreflightHook's allow/deny branches call through a real
+// SelfSubjectAccessReview against info.Clientset, which (matching the
+// rest of this package) is the concrete *kubernetes.Clientset rather
+// than the kubernetes.Interface a fake clientset implements, so only the
+// nil-clientset short-circuit is reachable without a live API server.
+func TestRBACPreflightHookRunSkipsCheckWithNilClientset(t *testing.T) {
+	hook := NewRBACPreflightHook("get", "pods")
+
+	if hook.Phase() != PreExecute {
+		t.Fatalf("Phase() = %v, want PreExecute", hook.Phase())
+	}
+	if hook.Weight() != -100 {
+		t.Fatalf("Weight() = %d, want -100", hook.Weight())
+	}
+
+	info := HandlerInfo{Name: "rbac-test-handler", Namespace: "default"}
+	if err := hook.Run(context.Background(), info, nil, nil); err != nil {
+		t.Fatalf("Run with a nil Clientset should skip the preflight check, got error: %v", err)
+	}
+}
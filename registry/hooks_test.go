@@ -0,0 +1,103 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// recordingHook appends its name to a shared slice when run, so tests
+// can assert the order hooks ran in.
+type recordingHook struct {
+	name   string
+	phase  HookPhase
+	weight int
+	err    error
+	calls  *[]string
+}
+
+func (h *recordingHook) Name() string     { return h.name }
+func (h *recordingHook) Phase() HookPhase { return h.phase }
+func (h *recordingHook) Weight() int      { return h.weight }
+
+func (h *recordingHook) Run(ctx context.Context, info HandlerInfo, result interface{}, err error) error {
+	*h.calls = append(*h.calls, h.name)
+	return h.err
+}
+
+// withCleanHookState snapshots and restores the package-level hook
+// registry and failure policy so each test starts from a blank slate
+// and doesn't leak hooks into other tests.
+func withCleanHookState(t *testing.T) {
+	t.Helper()
+
+	hooksMu.Lock()
+	savedHooks := hooks
+	savedPolicy := failurePolicy
+	hooks = make(map[HookPhase][]Hook)
+	failurePolicy = Abort
+	hooksMu.Unlock()
+
+	t.Cleanup(func() {
+		hooksMu.Lock()
+		hooks = savedHooks
+		failurePolicy = savedPolicy
+		hooksMu.Unlock()
+	})
+}
+
+func TestRunHooksOrdersByAscendingWeight(t *testing.T) {
+	withCleanHookState(t)
+
+	var calls []string
+	RegisterHook(&recordingHook{name: "metrics", phase: PostExecute, weight: 10, calls: &calls})
+	RegisterHook(&recordingHook{name: "rbac", phase: PostExecute, weight: -100, calls: &calls})
+	RegisterHook(&recordingHook{name: "audit", phase: PostExecute, weight: 0, calls: &calls})
+
+	if err := runHooks(context.Background(), PostExecute, HandlerInfo{}, nil, nil); err != nil {
+		t.Fatalf("runHooks: %v", err)
+	}
+
+	want := []string{"rbac", "audit", "metrics"}
+	if len(calls) != len(want) {
+		t.Fatalf("calls = %v, want %v", calls, want)
+	}
+	for i, name := range want {
+		if calls[i] != name {
+			t.Fatalf("calls = %v, want %v", calls, want)
+		}
+	}
+}
+
+func TestRunHooksAbortPolicyStopsAtFirstError(t *testing.T) {
+	withCleanHookState(t)
+
+	var calls []string
+	failing := errors.New("boom")
+	RegisterHook(&recordingHook{name: "first", phase: PreExecute, weight: 0, calls: &calls, err: failing})
+	RegisterHook(&recordingHook{name: "second", phase: PreExecute, weight: 1, calls: &calls})
+
+	err := runHooks(context.Background(), PreExecute, HandlerInfo{}, nil, nil)
+	if !errors.Is(err, failing) {
+		t.Fatalf("runHooks error = %v, want %v", err, failing)
+	}
+	if len(calls) != 1 || calls[0] != "first" {
+		t.Fatalf("calls = %v, want only [first] to have run under Abort policy", calls)
+	}
+}
+
+func TestRunHooksContinuePolicyRunsRemainingHooks(t *testing.T) {
+	withCleanHookState(t)
+	SetFailurePolicy(Continue)
+
+	var calls []string
+	RegisterHook(&recordingHook{name: "first", phase: PreExecute, weight: 0, calls: &calls, err: errors.New("boom")})
+	RegisterHook(&recordingHook{name: "second", phase: PreExecute, weight: 1, calls: &calls})
+
+	if err := runHooks(context.Background(), PreExecute, HandlerInfo{}, nil, nil); err != nil {
+		t.Fatalf("runHooks under Continue policy should swallow hook errors, got %v", err)
+	}
+	if len(calls) != 2 || calls[0] != "first" || calls[1] != "second" {
+		t.Fatalf("calls = %v, want [first second] to both run under Continue policy", calls)
+	}
+}
@@ -0,0 +1,208 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PipelineContext is shared across a pipeline's steps: each step's
+// result is stored under its handler name and is visible to every step
+// that runs after it, including as the params passed to ParamHandler
+// steps.
+type PipelineContext struct {
+	mu   sync.RWMutex
+	data map[string]interface{}
+}
+
+func newPipelineContext(seed map[string]interface{}) *PipelineContext {
+	pctx := &PipelineContext{data: make(map[string]interface{}, len(seed))}
+	for k, v := range seed {
+		pctx.data[k] = v
+	}
+
+	return pctx
+}
+
+// Get returns the value stored under key and whether it was present.
+func (p *PipelineContext) Get(key string) (interface{}, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	v, ok := p.data[key]
+
+	return v, ok
+}
+
+func (p *PipelineContext) set(key string, value interface{}) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.data[key] = value
+}
+
+// snapshot returns a shallow copy of the context's data, safe to hand to
+// a concurrently-running step or return as a pipeline's final result.
+func (p *PipelineContext) snapshot() map[string]interface{} {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	out := make(map[string]interface{}, len(p.data))
+	for k, v := range p.data {
+		out[k] = v
+	}
+
+	return out
+}
+
+// pipelineStep is one stage of a pipeline: a single handler name for a
+// Then step, or several names to run concurrently for a Parallel step.
+type pipelineStep struct {
+	names []string
+}
+
+// pipelinePathKey is the context key under which the set of pipeline
+// names currently executing in this call chain is stored, so a nested
+// pipeline can detect a cycle back to an ancestor.
+type pipelinePathKey struct{}
+
+// pipelinePathFrom returns the in-flight pipeline names for ctx, or nil
+// if none are running yet.
+func pipelinePathFrom(ctx context.Context) map[string]bool {
+	path, _ := ctx.Value(pipelinePathKey{}).(map[string]bool)
+	return path
+}
+
+// withPipelineName returns a context carrying name added to the
+// in-flight pipeline set, and an error if name is already in-flight,
+// meaning the pipeline graph has a cycle back to itself.
+func withPipelineName(ctx context.Context, name string) (context.Context, error) {
+	path := pipelinePathFrom(ctx)
+	if path[name] {
+		return ctx, fmt.Errorf("pipeline cycle detected: %s is already running in this execution", name)
+	}
+
+	next := make(map[string]bool, len(path)+1)
+	for k := range path {
+		next[k] = true
+	}
+	next[name] = true
+
+	return context.WithValue(ctx, pipelinePathKey{}, next), nil
+}
+
+// CompositeHandler chains registered handlers into a DAG: sequential
+// Then steps and fan-out Parallel steps, resolved from the registry by
+// name at execution time. It satisfies Handler and ParamHandler so it
+// can itself be registered, executed, and nested in another pipeline.
+type CompositeHandler struct {
+	name  string
+	steps []pipelineStep
+}
+
+// NewPipeline starts building a composite handler named name.
+func NewPipeline(name string) *CompositeHandler {
+	return &CompositeHandler{name: name}
+}
+
+// Then appends a sequential step that runs handlerName after every
+// earlier step has finished.
+func (c *CompositeHandler) Then(handlerName string) *CompositeHandler {
+	c.steps = append(c.steps, pipelineStep{names: []string{handlerName}})
+	return c
+}
+
+// Parallel appends a step that fans out to every named handler
+// concurrently and fans back in before the next step runs.
+func (c *CompositeHandler) Parallel(handlerNames ...string) *CompositeHandler {
+	c.steps = append(c.steps, pipelineStep{names: handlerNames})
+	return c
+}
+
+// Register adds the pipeline to the active backend under its own name,
+// the same way any other handler is registered.
+func (c *CompositeHandler) Register() {
+	Register(c)
+}
+
+func (c *CompositeHandler) Name() string { return c.name }
+
+func (c *CompositeHandler) Description() string {
+	return fmt.Sprintf("composite pipeline (%d step(s))", len(c.steps))
+}
+
+// Execute runs the pipeline with no seed parameters.
+func (c *CompositeHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return c.run(ctx, clientset, namespace, nil)
+}
+
+// ExecuteWithParams runs the pipeline, seeding its PipelineContext with
+// params before the first step runs.
+func (c *CompositeHandler) ExecuteWithParams(ctx context.Context, clientset *kubernetes.Clientset, namespace string, params map[string]interface{}) (interface{}, error) {
+	return c.run(ctx, clientset, namespace, params)
+}
+
+func (c *CompositeHandler) run(ctx context.Context, clientset *kubernetes.Clientset, namespace string, seed map[string]interface{}) (interface{}, error) {
+	ctx, err := withPipelineName(ctx, c.name)
+	if err != nil {
+		return nil, err
+	}
+
+	pctx := newPipelineContext(seed)
+
+	for _, step := range c.steps {
+		if len(step.names) == 1 {
+			name := step.names[0]
+			result, err := runPipelineStep(ctx, name, clientset, namespace, pctx)
+			if err != nil {
+				return pctx.snapshot(), fmt.Errorf("pipeline %s: step %s: %w", c.name, name, err)
+			}
+			pctx.set(name, result)
+			continue
+		}
+
+		g, gctx := errgroup.WithContext(ctx)
+		results := make([]interface{}, len(step.names))
+
+		for i, name := range step.names {
+			i, name := i, name
+			g.Go(func() error {
+				result, err := runPipelineStep(gctx, name, clientset, namespace, pctx)
+				if err != nil {
+					return fmt.Errorf("step %s: %w", name, err)
+				}
+				results[i] = result
+				return nil
+			})
+		}
+
+		if err := g.Wait(); err != nil {
+			return pctx.snapshot(), fmt.Errorf("pipeline %s: %w", c.name, err)
+		}
+
+		for i, name := range step.names {
+			pctx.set(name, results[i])
+		}
+	}
+
+	return pctx.snapshot(), nil
+}
+
+// runPipelineStep resolves name from the registry and executes it,
+// passing the pipeline's accumulated results as params when the handler
+// implements ParamHandler.
+func runPipelineStep(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string, pctx *PipelineContext) (interface{}, error) {
+	handler, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := handler.(ParamHandler); ok {
+		return ExecuteWithParams(ctx, name, clientset, namespace, pctx.snapshot())
+	}
+
+	return Execute(ctx, name, clientset, namespace)
+}
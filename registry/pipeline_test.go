@@ -0,0 +1,127 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// pipelineSourceHandler ignores params and always returns value.
+type pipelineSourceHandler struct {
+	name  string
+	value interface{}
+}
+
+func (h *pipelineSourceHandler) Name() string        { return h.name }
+func (h *pipelineSourceHandler) Description() string { return "fake pipeline source handler for tests" }
+func (h *pipelineSourceHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return h.value, nil
+}
+
+// pipelineParamHandler is a ParamHandler that reads upstream's result out
+// of params and folds it into its own result, so tests can assert that
+// Then steps actually thread the PipelineContext through.
+type pipelineParamHandler struct {
+	name     string
+	upstream string
+}
+
+func (h *pipelineParamHandler) Name() string        { return h.name }
+func (h *pipelineParamHandler) Description() string { return "fake pipeline param handler for tests" }
+func (h *pipelineParamHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return nil, fmt.Errorf("%s requires params", h.name)
+}
+func (h *pipelineParamHandler) ExecuteWithParams(ctx context.Context, clientset *kubernetes.Clientset, namespace string, params map[string]interface{}) (interface{}, error) {
+	upstream, ok := params[h.upstream]
+	if !ok {
+		return nil, fmt.Errorf("%s: missing upstream result %q", h.name, h.upstream)
+	}
+	return fmt.Sprintf("%s(%v)", h.name, upstream), nil
+}
+
+func TestCompositeHandlerThenThreadsResultsThroughPipelineContext(t *testing.T) {
+	source := &pipelineSourceHandler{name: "test-pipeline-then-source", value: "seed"}
+	sink := &pipelineParamHandler{name: "test-pipeline-then-sink", upstream: source.name}
+	Register(source)
+	Register(sink)
+
+	pipeline := NewPipeline("test-pipeline-then-chain").Then(source.name).Then(sink.name)
+	Register(pipeline)
+
+	result, err := Execute(context.Background(), pipeline.Name(), nil, "")
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	snapshot, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("result = %T, want map[string]interface{}", result)
+	}
+	if snapshot[source.name] != "seed" {
+		t.Fatalf("snapshot[%s] = %v, want seed", source.name, snapshot[source.name])
+	}
+	want := fmt.Sprintf("%s(seed)", sink.name)
+	if snapshot[sink.name] != want {
+		t.Fatalf("snapshot[%s] = %v, want %s", sink.name, snapshot[sink.name], want)
+	}
+}
+
+func TestCompositeHandlerParallelAggregatesFanInResults(t *testing.T) {
+	a := &pipelineSourceHandler{name: "test-pipeline-parallel-a", value: "a-result"}
+	b := &pipelineSourceHandler{name: "test-pipeline-parallel-b", value: "b-result"}
+	Register(a)
+	Register(b)
+
+	pipeline := NewPipeline("test-pipeline-parallel-fanout").Parallel(a.name, b.name)
+	Register(pipeline)
+
+	result, err := Execute(context.Background(), pipeline.Name(), nil, "")
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	snapshot, ok := result.(map[string]interface{})
+	if !ok {
+		t.Fatalf("result = %T, want map[string]interface{}", result)
+	}
+	if snapshot[a.name] != "a-result" {
+		t.Fatalf("snapshot[%s] = %v, want a-result", a.name, snapshot[a.name])
+	}
+	if snapshot[b.name] != "b-result" {
+		t.Fatalf("snapshot[%s] = %v, want b-result", b.name, snapshot[b.name])
+	}
+}
+
+func TestCompositeHandlerSelfCycleReturnsErrorInsteadOfRecursing(t *testing.T) {
+	pipeline := NewPipeline("test-pipeline-self-cycle")
+	pipeline.Then(pipeline.Name())
+	Register(pipeline)
+
+	_, err := Execute(context.Background(), pipeline.Name(), nil, "")
+	if err == nil {
+		t.Fatal("expected a cycle-detection error, got nil")
+	}
+	if !strings.Contains(err.Error(), "cycle detected") {
+		t.Fatalf("error = %q, want it to mention a detected cycle", err.Error())
+	}
+}
+
+func TestCompositeHandlerMutualCycleReturnsErrorInsteadOfRecursing(t *testing.T) {
+	outer := NewPipeline("test-pipeline-mutual-outer")
+	inner := NewPipeline("test-pipeline-mutual-inner")
+	inner.Then(outer.Name())
+	outer.Then(inner.Name())
+	Register(outer)
+	Register(inner)
+
+	_, err := Execute(context.Background(), outer.Name(), nil, "")
+	if err == nil {
+		t.Fatal("expected a cycle-detection error, got nil")
+	}
+	if !strings.Contains(err.Error(), "cycle detected") {
+		t.Fatalf("error = %q, want it to mention a detected cycle", err.Error())
+	}
+}
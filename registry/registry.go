@@ -2,9 +2,10 @@ package registry
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"time"
+
 	"k8s.io/client-go/kubernetes"
-	"sync"
 )
 
 // Handler interface (re-declared to avoid circular dependency)
@@ -14,65 +15,108 @@ type Handler interface {
 	Description() string
 }
 
-// Registry manages all registered handlers
-type Registry struct {
-	handlers map[string]Handler
-	mu       sync.RWMutex
+// ParamHandler is implemented by handlers that accept extra parameters,
+// such as pipeline steps that need the prior step's result. Handlers
+// that don't implement it still work with Execute and pipelines alike;
+// they just can't be parameterized.
+type ParamHandler interface {
+	Handler
+	ExecuteWithParams(ctx context.Context, clientset *kubernetes.Clientset, namespace string, params map[string]interface{}) (interface{}, error)
 }
 
-var (
-	globalRegistry = &Registry{
-		handlers: make(map[string]Handler),
-	}
-)
+// HandlerInfo is the context a Hook sees around a handler execution.
+// StartedAt is zero for PreExecute hooks and set to the time the
+// handler was invoked for PostExecute and OnError hooks.
+type HandlerInfo struct {
+	Name        string
+	Description string
+	Namespace   string
+	Clientset   *kubernetes.Clientset
+	StartedAt   time.Time
+}
 
-// Register adds a new handler to the global registry
+// Register adds a new handler to the active backend.
 // This is called from init() functions in handler packages
 func Register(handler Handler) {
-	globalRegistry.mu.Lock()
-	defer globalRegistry.mu.Unlock()
-	
-	name := handler.Name()
-	if _, exists := globalRegistry.handlers[name]; exists {
-		panic(fmt.Sprintf("handler %s already registered", name))
+	backend := currentBackend()
+
+	if err := backend.Register(handler); err != nil {
+		panic(err)
 	}
-	
-	globalRegistry.handlers[name] = handler
-	fmt.Printf("[Registry] Registered handler: %s - %s\n", name, handler.Description())
 }
 
-// Get retrieves a handler by name
+// Get retrieves a handler by name from the active backend
 func Get(name string) (Handler, error) {
-	globalRegistry.mu.RLock()
-	defer globalRegistry.mu.RUnlock()
-	
-	handler, exists := globalRegistry.handlers[name]
-	if !exists {
-		return nil, fmt.Errorf("handler %s not found", name)
-	}
-	
-	return handler, nil
+	return currentBackend().Get(name)
 }
 
-// List returns all registered handler names and descriptions
+// List returns all registered handler names and descriptions from the
+// active backend
 func List() map[string]string {
-	globalRegistry.mu.RLock()
-	defer globalRegistry.mu.RUnlock()
-	
-	result := make(map[string]string)
-	for name, handler := range globalRegistry.handlers {
-		result[name] = handler.Description()
-	}
-	
-	return result
+	return currentBackend().List()
 }
 
-// Execute runs a specific handler by name
+// Execute runs a specific handler by name against the active backend,
+// running any registered PreExecute, PostExecute and OnError hooks
+// around the call.
 func Execute(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return executeHandler(ctx, name, clientset, namespace, nil)
+}
+
+// ExecuteWithParams runs a specific handler by name, passing it params.
+// Handlers that implement ParamHandler receive params via
+// ExecuteWithParams; others ignore params and run as they would under
+// Execute. The same hooks as Execute run around the call.
+func ExecuteWithParams(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string, params map[string]interface{}) (interface{}, error) {
+	return executeHandler(ctx, name, clientset, namespace, params)
+}
+
+// executeHandler is the shared implementation behind Execute and
+// ExecuteWithParams: resolve the handler, run it (with params if given
+// and supported), and run the PreExecute/PostExecute/OnError hooks
+// around the call.
+func executeHandler(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string, params map[string]interface{}) (interface{}, error) {
 	handler, err := Get(name)
 	if err != nil {
 		return nil, err
 	}
-	
-	return handler.Execute(ctx, clientset, namespace)
+
+	info := HandlerInfo{
+		Name:        handler.Name(),
+		Description: handler.Description(),
+		Namespace:   namespace,
+		Clientset:   clientset,
+	}
+
+	if err := runHooks(ctx, PreExecute, info, nil, nil); err != nil {
+		return nil, err
+	}
+
+	info.StartedAt = time.Now()
+
+	backend := currentBackend()
+
+	var result interface{}
+	var execErr error
+	if paramHandler, ok := handler.(ParamHandler); ok && params != nil {
+		result, execErr = paramHandler.ExecuteWithParams(ctx, clientset, namespace, params)
+		if recorder, ok := backend.(StatusRecorder); ok {
+			recorder.RecordExecution(ctx, name, execErr)
+		}
+	} else {
+		result, execErr = backend.Execute(ctx, name, clientset, namespace)
+	}
+
+	if execErr != nil {
+		if err := runHooks(ctx, OnError, info, result, execErr); err != nil {
+			return result, errors.Join(execErr, err)
+		}
+		return result, execErr
+	}
+
+	if err := runHooks(ctx, PostExecute, info, result, nil); err != nil {
+		return result, err
+	}
+
+	return result, nil
 }
@@ -0,0 +1,132 @@
+package registry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Scope describes how ExecuteScoped should fan a handler out across a
+// cluster: which namespaces to run it in, an optional label/field
+// selector for handlers that honor one, and how many namespaces to run
+// concurrently.
+type Scope struct {
+	// Namespaces to run the handler in. An empty string runs the
+	// handler once in all-namespaces mode (the handler's own list
+	// calls decide what "all" means). A nil or empty slice is treated
+	// as []string{""}.
+	Namespaces []string
+
+	LabelSelector string
+	FieldSelector string
+
+	// Parallelism caps how many namespaces run concurrently. Zero or
+	// negative means "one worker per namespace".
+	Parallelism int
+}
+
+// SelectorAware is implemented by handlers that want to honor a Scope's
+// label/field selector. The registry calls ListOptions to build the
+// metav1.ListOptions for the current scope, then threads it through to
+// ParamHandler.ExecuteWithParams under the "listOptions" key for
+// handlers that also implement ParamHandler. Handlers that don't
+// implement SelectorAware run unchanged, ignoring the selector.
+type SelectorAware interface {
+	Handler
+	ListOptions(scope Scope) metav1.ListOptions
+}
+
+// ExecuteScoped runs the named handler once per namespace in scope,
+// using a worker pool bounded by scope.Parallelism, and aggregates the
+// results keyed by namespace. It returns whatever partial results
+// succeeded alongside a combined error for any namespaces that failed.
+func ExecuteScoped(ctx context.Context, name string, clientset *kubernetes.Clientset, scope Scope) (map[string]interface{}, error) {
+	namespaces := scope.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	parallelism := scope.Parallelism
+	if parallelism <= 0 {
+		parallelism = len(namespaces)
+	}
+
+	type outcome struct {
+		namespace string
+		result    interface{}
+		err       error
+	}
+
+	work := make(chan string)
+	outcomes := make(chan outcome, len(namespaces))
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallelism; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ns := range work {
+				result, err := executeScopedOne(ctx, name, clientset, ns, scope)
+				outcomes <- outcome{namespace: ns, result: result, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(work)
+		for _, ns := range namespaces {
+			select {
+			case work <- ns:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		wg.Wait()
+		close(outcomes)
+	}()
+
+	results := make(map[string]interface{}, len(namespaces))
+	var errs []error
+	for o := range outcomes {
+		if o.err != nil {
+			errs = append(errs, fmt.Errorf("namespace %q: %w", o.namespace, o.err))
+			continue
+		}
+		results[o.namespace] = o.result
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// executeScopedOne runs the handler for a single namespace, threading
+// the scope's selector through to a SelectorAware/ParamHandler handler
+// when both are implemented.
+func executeScopedOne(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string, scope Scope) (interface{}, error) {
+	handler, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	selectorAware, isSelectorAware := handler.(SelectorAware)
+	_, isParamHandler := handler.(ParamHandler)
+
+	switch {
+	case isSelectorAware && isParamHandler:
+		params := map[string]interface{}{"listOptions": selectorAware.ListOptions(scope)}
+		return ExecuteWithParams(ctx, name, clientset, namespace, params)
+	case isSelectorAware && (scope.LabelSelector != "" || scope.FieldSelector != ""):
+		// The handler asked to see selectors but can't receive the
+		// ListOptions we built for it without ParamHandler; fail loudly
+		// instead of silently running as if no selector were given.
+		return nil, fmt.Errorf("handler %s implements SelectorAware but not ParamHandler, so its selector cannot be applied", name)
+	default:
+		return Execute(ctx, name, clientset, namespace)
+	}
+}
@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"k8s.io/client-go/kubernetes"
+)
+
+// scopedTestHandler fails for any namespace in failFor, and otherwise
+// succeeds with its own namespace as the result, so tests can assert
+// ExecuteScoped aggregates both outcomes correctly.
+type scopedTestHandler struct {
+	name    string
+	failFor map[string]bool
+}
+
+func (h *scopedTestHandler) Name() string        { return h.name }
+func (h *scopedTestHandler) Description() string { return "fake scoped handler for tests" }
+func (h *scopedTestHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	if h.failFor[namespace] {
+		return nil, fmt.Errorf("forced failure in %s", namespace)
+	}
+	return namespace, nil
+}
+
+func TestExecuteScopedAggregatesPartialResultsAndErrors(t *testing.T) {
+	handler := &scopedTestHandler{
+		name:    "test-scope-partial",
+		failFor: map[string]bool{"bad-ns": true},
+	}
+	Register(handler)
+
+	scope := Scope{Namespaces: []string{"good-ns-1", "bad-ns", "good-ns-2"}}
+
+	results, err := ExecuteScoped(context.Background(), handler.name, nil, scope)
+
+	if err == nil {
+		t.Fatal("expected a non-nil error for the failing namespace")
+	}
+	if got := err.Error(); !strings.Contains(got, "bad-ns") {
+		t.Fatalf("error %q does not mention the failing namespace", got)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("results = %v, want exactly the 2 succeeding namespaces", results)
+	}
+	if results["good-ns-1"] != "good-ns-1" {
+		t.Fatalf("results[good-ns-1] = %v, want good-ns-1", results["good-ns-1"])
+	}
+	if results["good-ns-2"] != "good-ns-2" {
+		t.Fatalf("results[good-ns-2] = %v, want good-ns-2", results["good-ns-2"])
+	}
+	if _, ok := results["bad-ns"]; ok {
+		t.Fatal("results should not contain an entry for the failing namespace")
+	}
+}
+
+func TestExecuteScopedAllSuccessReturnsNilError(t *testing.T) {
+	handler := &scopedTestHandler{name: "test-scope-all-success"}
+	Register(handler)
+
+	scope := Scope{Namespaces: []string{"ns-a", "ns-b"}}
+
+	results, err := ExecuteScoped(context.Background(), handler.name, nil, scope)
+	if err != nil {
+		t.Fatalf("ExecuteScoped: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("results = %v, want 2 entries", results)
+	}
+}
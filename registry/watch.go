@@ -0,0 +1,238 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+// WatchHandler is implemented by handlers that can stream Added/Modified/
+// Deleted events from the Kubernetes watch API instead of (or in addition
+// to) returning a one-shot snapshot from Execute.
+type WatchHandler interface {
+	Handler
+	Watch(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (<-chan watch.Event, error)
+}
+
+// watchEntry tracks the lifecycle of a single active watch so that
+// concurrent callers of Watch for the same handler share one underlying
+// Kubernetes watch connection instead of opening a new one each time.
+type watchEntry struct {
+	cancel   context.CancelFunc
+	bus      *eventBus
+	refCount int
+
+	// ready is closed once the entry's dial (the call to
+	// watchHandler.Watch) has completed, successfully or not. Callers
+	// that coalesce onto an in-flight dial wait on it before reporting
+	// success back to their own caller. dialErr is only meaningful after
+	// ready is closed.
+	ready   chan struct{}
+	dialErr error
+}
+
+// eventBus fans the events from a single handler watch out to any number
+// of subscribers.
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[int]chan watch.Event
+	nextID      int
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subscribers: make(map[int]chan watch.Event)}
+}
+
+func (b *eventBus) subscribe() (int, <-chan watch.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := b.nextID
+	b.nextID++
+	ch := make(chan watch.Event, 16)
+	b.subscribers[id] = ch
+
+	return id, ch
+}
+
+func (b *eventBus) publish(evt watch.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- evt:
+		default:
+			// Slow consumer; drop the event rather than block the watch pump.
+		}
+	}
+}
+
+func (b *eventBus) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, ch := range b.subscribers {
+		delete(b.subscribers, id)
+		close(ch)
+	}
+}
+
+// unsubscribe removes and closes a single subscriber's channel,
+// reporting whether it was still subscribed.
+func (b *eventBus) unsubscribe(ch <-chan watch.Event) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, sub := range b.subscribers {
+		if sub == ch {
+			delete(b.subscribers, id)
+			close(sub)
+			return true
+		}
+	}
+
+	return false
+}
+
+// watches tracks active watch entries independent of which Backend is
+// active: live watch connections are runtime state, not part of the
+// handler catalog a Backend persists.
+var watches = struct {
+	mu      sync.RWMutex
+	entries map[string]*watchEntry
+}{entries: make(map[string]*watchEntry)}
+
+// Watch subscribes to the named handler's event stream. The first call
+// for a given handler starts the underlying Kubernetes watch; subsequent
+// concurrent calls are coalesced onto the same connection via reference
+// counting. The returned channel is closed when StopWatch is called with
+// it, or for every subscriber once the handler's watch ends.
+//
+// The dial to watchHandler.Watch happens outside watches.mu, so a
+// slow or blocked connection for one handler doesn't stall Watch/
+// StopWatch calls for every other handler in the process; a reserved
+// entry lets concurrent callers for the same handler coalesce onto the
+// in-flight attempt in the meantime.
+func Watch(ctx context.Context, name string, clientset *kubernetes.Clientset, namespace string) (<-chan watch.Event, error) {
+	handler, err := Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	watchHandler, ok := handler.(WatchHandler)
+	if !ok {
+		return nil, fmt.Errorf("handler %s does not support watch", name)
+	}
+
+	watches.mu.Lock()
+	entry, exists := watches.entries[name]
+	if exists {
+		entry.refCount++
+		_, ch := entry.bus.subscribe()
+		watches.mu.Unlock()
+
+		select {
+		case <-entry.ready:
+		case <-ctx.Done():
+			// Give up waiting for the in-flight dial; release our
+			// reservation on it instead of leaking it. If the dial has
+			// already finished and torn the entry down, there's nothing
+			// left to release and StopWatch's error is expected.
+			_ = StopWatch(name, ch)
+			return nil, ctx.Err()
+		}
+
+		if entry.dialErr != nil {
+			return nil, entry.dialErr
+		}
+
+		return ch, nil
+	}
+
+	entry = &watchEntry{bus: newEventBus(), ready: make(chan struct{})}
+	watches.entries[name] = entry
+	entry.refCount++
+	_, ch := entry.bus.subscribe()
+	watches.mu.Unlock()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	source, err := watchHandler.Watch(watchCtx, clientset, namespace)
+
+	watches.mu.Lock()
+	if err != nil {
+		entry.dialErr = err
+		cancel()
+		delete(watches.entries, name)
+		close(entry.ready)
+		entry.bus.closeAll()
+		watches.mu.Unlock()
+
+		return nil, err
+	}
+	entry.cancel = cancel
+	close(entry.ready)
+	watches.mu.Unlock()
+
+	go pumpEvents(name, source)
+
+	return ch, nil
+}
+
+// pumpEvents forwards events from a handler's watch source into its event
+// bus for the lifetime of the watch, then tears down the entry and closes
+// all subscriber channels once the source closes (on cancellation, or if
+// the underlying watch connection drops).
+func pumpEvents(name string, source <-chan watch.Event) {
+	for evt := range source {
+		watches.mu.RLock()
+		entry, exists := watches.entries[name]
+		watches.mu.RUnlock()
+
+		if !exists {
+			return
+		}
+		entry.bus.publish(evt)
+	}
+
+	watches.mu.Lock()
+	entry, exists := watches.entries[name]
+	if exists {
+		delete(watches.entries, name)
+	}
+	watches.mu.Unlock()
+
+	if exists {
+		entry.bus.closeAll()
+	}
+}
+
+// StopWatch unsubscribes ch, a channel previously returned by Watch for
+// name, and releases its reference on the underlying watch. The
+// Kubernetes watch connection is torn down once the last subscriber has
+// stopped.
+func StopWatch(name string, ch <-chan watch.Event) error {
+	watches.mu.Lock()
+	defer watches.mu.Unlock()
+
+	entry, exists := watches.entries[name]
+	if !exists {
+		return fmt.Errorf("no active watch for handler %s", name)
+	}
+
+	if !entry.bus.unsubscribe(ch) {
+		return fmt.Errorf("channel is not subscribed to handler %s", name)
+	}
+
+	entry.refCount--
+	if entry.refCount <= 0 {
+		entry.cancel()
+		delete(watches.entries, name)
+		entry.bus.closeAll()
+	}
+
+	return nil
+}
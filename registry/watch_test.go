@@ -0,0 +1,334 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+func TestEventBusPublishFansOutToAllSubscribers(t *testing.T) {
+	bus := newEventBus()
+
+	_, ch1 := bus.subscribe()
+	_, ch2 := bus.subscribe()
+
+	bus.publish(watch.Event{Type: watch.Added})
+
+	select {
+	case evt := <-ch1:
+		if evt.Type != watch.Added {
+			t.Fatalf("ch1 got event type %q, want Added", evt.Type)
+		}
+	default:
+		t.Fatal("ch1 did not receive the published event")
+	}
+
+	select {
+	case evt := <-ch2:
+		if evt.Type != watch.Added {
+			t.Fatalf("ch2 got event type %q, want Added", evt.Type)
+		}
+	default:
+		t.Fatal("ch2 did not receive the published event")
+	}
+}
+
+func TestEventBusUnsubscribeStopsDeliveryAndClosesChannel(t *testing.T) {
+	bus := newEventBus()
+
+	_, ch := bus.subscribe()
+
+	if !bus.unsubscribe(ch) {
+		t.Fatal("unsubscribe of a live subscriber returned false")
+	}
+
+	if _, open := <-ch; open {
+		t.Fatal("channel was not closed after unsubscribe")
+	}
+
+	if bus.unsubscribe(ch) {
+		t.Fatal("unsubscribe of an already-removed channel returned true")
+	}
+}
+
+// fakeWatchHandler is a minimal WatchHandler whose Watch returns a
+// channel the test controls directly, so Watch/StopWatch refcounting
+// can be exercised without a real Kubernetes watch connection.
+type fakeWatchHandler struct {
+	name   string
+	source chan watch.Event
+}
+
+func (h *fakeWatchHandler) Name() string        { return h.name }
+func (h *fakeWatchHandler) Description() string { return "fake watch handler for tests" }
+func (h *fakeWatchHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return nil, nil
+}
+func (h *fakeWatchHandler) Watch(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (<-chan watch.Event, error) {
+	return h.source, nil
+}
+
+// blockingWatchHandler's Watch doesn't return until released is closed,
+// simulating a slow connection to the underlying Kubernetes watch API.
+// If failDial is also closed by the time released fires, Watch reports
+// a dial failure instead of succeeding.
+type blockingWatchHandler struct {
+	name     string
+	released chan struct{}
+	dialed   chan struct{}
+	failDial chan struct{}
+}
+
+func (h *blockingWatchHandler) Name() string        { return h.name }
+func (h *blockingWatchHandler) Description() string { return "fake blocking watch handler for tests" }
+func (h *blockingWatchHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return nil, nil
+}
+func (h *blockingWatchHandler) Watch(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (<-chan watch.Event, error) {
+	h.dialed <- struct{}{}
+	<-h.released
+
+	select {
+	case <-h.failDial:
+		return nil, fmt.Errorf("dial failed for %s", h.name)
+	default:
+		return make(chan watch.Event), nil
+	}
+}
+
+func TestWatchDoesNotBlockOtherHandlersWhileDialingOneHandler(t *testing.T) {
+	slow := &blockingWatchHandler{
+		name:     "test-watch-slow-dial",
+		released: make(chan struct{}),
+		dialed:   make(chan struct{}, 1),
+	}
+	fast := &fakeWatchHandler{name: "test-watch-unblocked-by-slow-dial", source: make(chan watch.Event, 1)}
+	Register(slow)
+	Register(fast)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Watch(context.Background(), slow.name, nil, "")
+	}()
+
+	select {
+	case <-slow.dialed:
+	case <-time.After(time.Second):
+		t.Fatal("slow handler's Watch was never dialed")
+	}
+
+	fastDone := make(chan struct{})
+	go func() {
+		defer close(fastDone)
+		if _, err := Watch(context.Background(), fast.name, nil, ""); err != nil {
+			t.Errorf("Watch(fast): %v", err)
+		}
+	}()
+
+	select {
+	case <-fastDone:
+	case <-time.After(time.Second):
+		t.Fatal("Watch for an unrelated handler was blocked by a slow in-flight dial")
+	}
+
+	close(slow.released)
+	<-done
+}
+
+func TestWatchCoalescesDuringInFlightDial(t *testing.T) {
+	slow := &blockingWatchHandler{
+		name:     "test-watch-coalesce-during-dial",
+		released: make(chan struct{}),
+		dialed:   make(chan struct{}, 1),
+	}
+	Register(slow)
+
+	firstDone := make(chan struct{})
+	go func() {
+		defer close(firstDone)
+		if _, err := Watch(context.Background(), slow.name, nil, ""); err != nil {
+			t.Errorf("first Watch call: %v", err)
+		}
+	}()
+
+	select {
+	case <-slow.dialed:
+	case <-time.After(time.Second):
+		t.Fatal("Watch was never dialed")
+	}
+
+	// A second caller coalescing onto the in-flight dial must wait for
+	// its outcome rather than reporting success before the connection is
+	// actually up.
+	secondDone := make(chan struct{})
+	go func() {
+		defer close(secondDone)
+		if _, err := Watch(context.Background(), slow.name, nil, ""); err != nil {
+			t.Errorf("second Watch call while the first is still dialing: %v", err)
+		}
+	}()
+
+	select {
+	case <-firstDone:
+		t.Fatal("first Watch call returned before the dial was released")
+	case <-secondDone:
+		t.Fatal("second Watch call returned before the dial was released")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(slow.released)
+	<-firstDone
+	<-secondDone
+
+	watches.mu.RLock()
+	entry := watches.entries[slow.name]
+	watches.mu.RUnlock()
+	if entry.refCount != 2 {
+		t.Fatalf("refCount = %d, want 2 (both calls coalesced onto one entry)", entry.refCount)
+	}
+}
+
+func TestWatchCoalescingCallerGetsTheRealDialError(t *testing.T) {
+	slow := &blockingWatchHandler{
+		name:     "test-watch-coalesce-dial-error",
+		released: make(chan struct{}),
+		dialed:   make(chan struct{}, 1),
+		failDial: make(chan struct{}),
+	}
+	Register(slow)
+
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := Watch(context.Background(), slow.name, nil, "")
+		firstErr <- err
+	}()
+
+	select {
+	case <-slow.dialed:
+	case <-time.After(time.Second):
+		t.Fatal("Watch was never dialed")
+	}
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := Watch(context.Background(), slow.name, nil, "")
+		secondErr <- err
+	}()
+
+	close(slow.failDial)
+	close(slow.released)
+
+	if err := <-firstErr; err == nil {
+		t.Fatal("first Watch call should surface the dial failure")
+	}
+	if err := <-secondErr; err == nil {
+		t.Fatal("second Watch call, coalesced onto the failed dial, should also surface the failure instead of a silently closed channel")
+	}
+}
+
+func TestWatchFailedDialTearsDownReservedEntry(t *testing.T) {
+	handler := &fakeFailingWatchHandler{name: "test-watch-failed-dial"}
+	Register(handler)
+
+	_, err := Watch(context.Background(), handler.name, nil, "")
+	if err == nil {
+		t.Fatal("expected an error from a failing dial")
+	}
+
+	watches.mu.RLock()
+	_, exists := watches.entries[handler.name]
+	watches.mu.RUnlock()
+	if exists {
+		t.Fatal("a failed dial should not leave a reserved entry behind")
+	}
+}
+
+// fakeFailingWatchHandler's Watch always errors, simulating a failed
+// connection to the underlying Kubernetes watch API.
+type fakeFailingWatchHandler struct {
+	name string
+}
+
+func (h *fakeFailingWatchHandler) Name() string        { return h.name }
+func (h *fakeFailingWatchHandler) Description() string { return "fake failing watch handler for tests" }
+func (h *fakeFailingWatchHandler) Execute(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (interface{}, error) {
+	return nil, nil
+}
+func (h *fakeFailingWatchHandler) Watch(ctx context.Context, clientset *kubernetes.Clientset, namespace string) (<-chan watch.Event, error) {
+	return nil, fmt.Errorf("dial failed for %s", h.name)
+}
+
+func TestWatchCoalescesConcurrentCallsOntoOneConnectionAndRefCounts(t *testing.T) {
+	handler := &fakeWatchHandler{name: "test-watch-refcount", source: make(chan watch.Event, 1)}
+	Register(handler)
+
+	ctx := context.Background()
+
+	ch1, err := Watch(ctx, handler.name, nil, "")
+	if err != nil {
+		t.Fatalf("first Watch call: %v", err)
+	}
+	ch2, err := Watch(ctx, handler.name, nil, "")
+	if err != nil {
+		t.Fatalf("second Watch call: %v", err)
+	}
+
+	watches.mu.RLock()
+	entry := watches.entries[handler.name]
+	watches.mu.RUnlock()
+	if entry == nil {
+		t.Fatal("no watch entry registered after Watch")
+	}
+	if entry.refCount != 2 {
+		t.Fatalf("refCount = %d, want 2 after two Watch calls", entry.refCount)
+	}
+
+	if err := StopWatch(handler.name, ch1); err != nil {
+		t.Fatalf("StopWatch(ch1): %v", err)
+	}
+	if _, open := <-ch1; open {
+		t.Fatal("ch1 was not closed by StopWatch")
+	}
+
+	watches.mu.RLock()
+	entry = watches.entries[handler.name]
+	watches.mu.RUnlock()
+	if entry == nil {
+		t.Fatal("watch entry was torn down after releasing only one of two references")
+	}
+	if entry.refCount != 1 {
+		t.Fatalf("refCount = %d, want 1 after releasing one of two references", entry.refCount)
+	}
+
+	if err := StopWatch(handler.name, ch2); err != nil {
+		t.Fatalf("StopWatch(ch2): %v", err)
+	}
+
+	watches.mu.RLock()
+	_, exists := watches.entries[handler.name]
+	watches.mu.RUnlock()
+	if exists {
+		t.Fatal("watch entry was not torn down after releasing the last reference")
+	}
+}
+
+func TestStopWatchUnknownChannelReturnsError(t *testing.T) {
+	handler := &fakeWatchHandler{name: "test-watch-unknown-channel", source: make(chan watch.Event, 1)}
+	Register(handler)
+
+	ch, err := Watch(context.Background(), handler.name, nil, "")
+	if err != nil {
+		t.Fatalf("Watch: %v", err)
+	}
+	defer StopWatch(handler.name, ch)
+
+	other := make(chan watch.Event)
+	if err := StopWatch(handler.name, other); err == nil {
+		t.Fatal("StopWatch with a channel never returned by Watch should error")
+	}
+}